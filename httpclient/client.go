@@ -0,0 +1,81 @@
+// Package httpclient provides a small, opinionated HTTP client for calling
+// upstreams and admin APIs: base URL + default header handling, a retry
+// policy for idempotent requests, and fluent request builders that take
+// care of draining and closing response bodies so connections get reused.
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Client is a reusable HTTP client bound to a base URL and a set of
+// defaults (headers, timeout, retry policy) applied to every request it
+// builds.
+type Client struct {
+	baseURL        *url.URL
+	httpClient     *http.Client
+	defaultHeaders http.Header
+	retry          RetryPolicy
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient overrides the underlying *http.Client, e.g. to reuse a
+// shared *http.Transport across clients.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithTimeout sets the Timeout of the underlying *http.Client, which bounds
+// each individual attempt (retries get a fresh timeout).
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) { c.httpClient.Timeout = d }
+}
+
+// WithHeader sets a default header sent with every request, e.g. an auth
+// token or a user-agent override.
+func WithHeader(key, value string) Option {
+	return func(c *Client) { c.defaultHeaders.Set(key, value) }
+}
+
+// WithRetryPolicy overrides the default retry policy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) { c.retry = p }
+}
+
+// New builds a Client for the given base URL, applying opts in order.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		baseURL:        u,
+		httpClient:     &http.Client{Timeout: 10 * time.Second},
+		defaultHeaders: make(http.Header),
+		retry:          DefaultRetryPolicy(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// NewRequest starts building a request for method and path, where path is
+// resolved against the client's base URL.
+func (c *Client) NewRequest(method, path string) *RequestBuilder {
+	return &RequestBuilder{
+		client: c,
+		ctx:    context.Background(),
+		method: method,
+		path:   path,
+		header: c.defaultHeaders.Clone(),
+	}
+}
@@ -0,0 +1,14 @@
+package httpclient
+
+import "context"
+
+// HealthCheck performs a GET against path and reports whether the upstream
+// responded without error, for use by operators probing backend liveness.
+func (c *Client) HealthCheck(ctx context.Context, path string) error {
+	resp, err := c.NewRequest("GET", path).WithContext(ctx).Do()
+	if err != nil {
+		return err
+	}
+	drainAndClose(resp.Body)
+	return nil
+}
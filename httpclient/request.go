@@ -0,0 +1,136 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RequestBuilder assembles a single request against a Client. Calls to its
+// setter methods return the builder itself so calls can be chained.
+type RequestBuilder struct {
+	client  *Client
+	ctx     context.Context
+	method  string
+	path    string
+	header  http.Header
+	body    []byte
+	bodyErr error
+}
+
+// WithContext attaches ctx to the request, e.g. for cancellation or
+// deadlines beyond the client's default timeout.
+func (b *RequestBuilder) WithContext(ctx context.Context) *RequestBuilder {
+	b.ctx = ctx
+	return b
+}
+
+// Header sets a header on the outgoing request.
+func (b *RequestBuilder) Header(key, value string) *RequestBuilder {
+	b.header.Set(key, value)
+	return b
+}
+
+// JSONBody marshals v as the request body and sets Content-Type to
+// application/json.
+func (b *RequestBuilder) JSONBody(v interface{}) *RequestBuilder {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		// Deferred to Do, which will surface the same error on send.
+		b.bodyErr = err
+		return b
+	}
+	b.header.Set("Content-Type", "application/json")
+	b.body = buf
+	return b
+}
+
+// Into sends the request and decodes a JSON response body into out. If out
+// is nil, the response body is still drained and closed but not decoded.
+func (b *RequestBuilder) Into(out interface{}) error {
+	resp, err := b.Do()
+	if err != nil {
+		return err
+	}
+	defer drainAndClose(resp.Body)
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("httpclient: %s %s: unexpected status %d", b.method, b.path, resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// Do sends the request, retrying according to the client's retry policy,
+// and returns the final response. If the last attempt still ends in a
+// retryable status, its body is discarded and an error is returned instead.
+// Callers are responsible for draining and closing resp.Body; prefer Into
+// for the common JSON case.
+func (b *RequestBuilder) Do() (*http.Response, error) {
+	if b.bodyErr != nil {
+		return nil, b.bodyErr
+	}
+
+	ref, err := b.client.baseURL.Parse(b.path)
+	if err != nil {
+		return nil, err
+	}
+	url := ref.String()
+	policy := b.client.retry
+
+	var lastErr error
+	var retryAfter string
+	for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(policy.backoff(attempt-1, retryAfter))
+		}
+		retryAfter = ""
+
+		var bodyReader io.Reader
+		if b.body != nil {
+			bodyReader = bytes.NewReader(b.body)
+		}
+
+		req, err := http.NewRequestWithContext(b.ctx, b.method, url, bodyReader)
+		if err != nil {
+			return nil, err
+		}
+		req.Header = b.header.Clone()
+
+		resp, err := b.client.httpClient.Do(req)
+		if err == nil && !policy.shouldRetry(b.method, resp.StatusCode, nil) {
+			return resp, nil
+		}
+
+		if err != nil {
+			lastErr = err
+			if !policy.shouldRetry(b.method, 0, err) {
+				return nil, err
+			}
+			continue
+		}
+
+		retryAfter = resp.Header.Get("Retry-After")
+		drainAndClose(resp.Body)
+		lastErr = fmt.Errorf("httpclient: %s %s: status %d", b.method, b.path, resp.StatusCode)
+		if attempt == policy.MaxAttempts-1 {
+			return nil, lastErr
+		}
+	}
+
+	return nil, lastErr
+}
+
+// drainAndClose reads body to completion and closes it so the underlying
+// connection can be reused by the transport's connection pool.
+func drainAndClose(body io.ReadCloser) {
+	io.Copy(io.Discard, body)
+	body.Close()
+}
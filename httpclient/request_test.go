@@ -0,0 +1,94 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestBuilderResendsBodyOnRetry(t *testing.T) {
+	var bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, WithRetryPolicy(RetryPolicy{
+		MaxAttempts:    2,
+		IdempotentOnly: true,
+	}))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	type payload struct {
+		Name string `json:"name"`
+	}
+
+	if err := c.NewRequest(http.MethodPut, "/").JSONBody(payload{Name: "widget"}).Into(nil); err != nil {
+		t.Fatalf("Into: %v", err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("got %d requests, want 2", len(bodies))
+	}
+	for i, b := range bodies {
+		if b != `{"name":"widget"}` {
+			t.Errorf("attempt %d: got body %q, want the full JSON payload on every attempt", i+1, b)
+		}
+	}
+}
+
+// TestRequestBuilderHonorsRetryAfterExactly guards against stacking the
+// policy's exponential backoff on top of a server-provided Retry-After: the
+// wait before the retry should be driven by the header alone, not the
+// header plus the normal top-of-loop backoff.
+func TestRequestBuilderHonorsRetryAfterExactly(t *testing.T) {
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 2,
+		BaseDelay:   2 * time.Second,
+		MaxDelay:    10 * time.Second,
+	}))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	start := time.Now()
+	if err := c.NewRequest(http.MethodGet, "/").Into(nil); err != nil {
+		t.Fatalf("Into: %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if requests != 2 {
+		t.Fatalf("got %d requests, want 2", requests)
+	}
+	// BaseDelay is set well above the 1s Retry-After so that, if the
+	// exponential backoff were stacked on top of it, elapsed would blow
+	// past this bound.
+	if elapsed > 1500*time.Millisecond {
+		t.Fatalf("waited %v before retrying, want ~1s (Retry-After only, not stacked with backoff)", elapsed)
+	}
+}
@@ -0,0 +1,66 @@
+package httpclient
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls whether and how a failed request is retried.
+type RetryPolicy struct {
+	MaxAttempts    int
+	BaseDelay      time.Duration
+	MaxDelay       time.Duration
+	IdempotentOnly bool
+}
+
+// DefaultRetryPolicy makes up to 3 attempts (at most 2 retries) for
+// idempotent requests, with exponential backoff and jitter capped at 5
+// seconds between attempts.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		BaseDelay:      200 * time.Millisecond,
+		MaxDelay:       5 * time.Second,
+		IdempotentOnly: true,
+	}
+}
+
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// shouldRetry reports whether a request with the given method, resulting
+// status code and error should be retried.
+func (p RetryPolicy) shouldRetry(method string, status int, err error) bool {
+	if p.IdempotentOnly && !idempotentMethods[method] {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// backoff computes the delay before retry n (0-indexed, so n=0 is the wait
+// before the second attempt), honoring a Retry-After given in whole seconds.
+// A Retry-After in any other form falls back to the exponential delay.
+func (p RetryPolicy) backoff(n int, retryAfter string) time.Duration {
+	if retryAfter != "" {
+		if secs, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+	}
+
+	delay := p.BaseDelay * time.Duration(1<<uint(n))
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay) + 1))
+	return delay/2 + jitter/2
+}
@@ -0,0 +1,35 @@
+package httpclient
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	p := DefaultRetryPolicy()
+
+	cases := []struct {
+		name   string
+		method string
+		status int
+		err    error
+		want   bool
+	}{
+		{"get 500 retries", http.MethodGet, http.StatusInternalServerError, nil, true},
+		{"get 429 retries", http.MethodGet, http.StatusTooManyRequests, nil, true},
+		{"get 200 does not retry", http.MethodGet, http.StatusOK, nil, false},
+		{"post 500 does not retry", http.MethodPost, http.StatusInternalServerError, nil, false},
+		{"put 500 retries", http.MethodPut, http.StatusInternalServerError, nil, true},
+		{"network error retries", http.MethodGet, 0, errors.New("dial tcp: timeout"), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := p.shouldRetry(tc.method, tc.status, tc.err)
+			if got != tc.want {
+				t.Errorf("shouldRetry(%q, %d, %v) = %v, want %v", tc.method, tc.status, tc.err, got, tc.want)
+			}
+		})
+	}
+}
@@ -1,21 +1,99 @@
 package main
 
 import (
+	"context"
+	"log"
 	"net/http"
+	"os/signal"
+	"syscall"
 	"time"
+
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/zzzdong/apireception/metrics"
+	"github.com/zzzdong/apireception/middleware"
+	"github.com/zzzdong/apireception/static"
 )
 
+// shutdownTimeout bounds how long we wait for in-flight requests to drain
+// before forcing the process to exit.
+const shutdownTimeout = 15 * time.Second
+
 func main() {
 
 	helloHandler := func(w http.ResponseWriter, r *http.Request) {
-		time.Sleep(time.Second * 1)
+		select {
+		case <-time.After(time.Second * 1):
+		case <-r.Context().Done():
+			return
+		}
 
-		w.Header().Add("X-Remote-Addr", r.RemoteAddr)
 		w.Write([]byte("Hello, world!\n"))
 	}
 
-	http.HandleFunc("/", helloHandler)
+	chain := []middleware.Middleware{
+		middleware.Recovery(nil),
+		middleware.AccessLog(nil),
+		middleware.CORS(middleware.CORSConfig{AllowedOrigins: []string{"*"}}),
+		middleware.Compress(),
+	}
+
+	const staticCacheBytes = 64 << 20 // 64MiB
 
-	http.ListenAndServe("0.0.0.0:5000", nil)
+	mux := http.NewServeMux()
+	mux.Handle("/", middleware.Chain(http.HandlerFunc(helloHandler), chain...))
+	mux.Handle("/static/", static.NewHandler("./public", "/static/", staticCacheBytes, true))
+	mux.Handle("/metrics", metrics.Handler())
 
+	tlsCfg := TLSConfig{
+		Enabled:     false,
+		HTTPSAddr:   "0.0.0.0:443",
+		Domains:     nil,
+		CacheDir:    "/var/cache/apireception/autocert",
+		ACMEStaging: false,
+	}
+
+	servers := []*http.Server{{
+		Addr:    "0.0.0.0:5000",
+		Handler: mux,
+	}}
+
+	var manager *autocert.Manager
+	if tlsCfg.Enabled {
+		manager = newAutocertManager(tlsCfg)
+		servers[0].Handler = acmeChallengeHandler(manager, nil)
+		servers = append(servers, newTLSServer(tlsCfg, manager, mux))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	for _, srv := range servers {
+		srv := srv
+		go func() {
+			var err error
+			if srv.TLSConfig != nil {
+				err = srv.ListenAndServeTLS("", "")
+			} else {
+				err = srv.ListenAndServe()
+			}
+			if err != nil && err != http.ErrServerClosed {
+				log.Fatalf("listen and serve %s: %v", srv.Addr, err)
+			}
+		}()
+	}
+
+	<-ctx.Done()
+	stop()
+
+	log.Println("shutting down server, waiting for in-flight requests to finish")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	for _, srv := range servers {
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Fatalf("server shutdown %s: %v", srv.Addr, err)
+		}
+	}
 }
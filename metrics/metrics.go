@@ -0,0 +1,46 @@
+// Package metrics exposes a handful of process-wide counters over a
+// /metrics HTTP endpoint, for consumption by lightweight scrapers that
+// don't need full Prometheus text format.
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+)
+
+// Counter is a monotonically increasing, concurrency-safe counter.
+type Counter struct {
+	value int64
+}
+
+// Inc increments the counter by one.
+func (c *Counter) Inc() {
+	atomic.AddInt64(&c.value, 1)
+}
+
+// Value returns the counter's current value.
+func (c *Counter) Value() int64 {
+	return atomic.LoadInt64(&c.value)
+}
+
+var (
+	// StaticCacheHits counts static file serves satisfied from the
+	// in-memory read cache.
+	StaticCacheHits Counter
+	// StaticCacheMisses counts static file serves that required reading
+	// from disk.
+	StaticCacheMisses Counter
+)
+
+// Handler serves the registered counters as a JSON object, suitable for
+// mounting at /metrics.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]int64{
+			"static_cache_hits":   StaticCacheHits.Value(),
+			"static_cache_misses": StaticCacheMisses.Value(),
+		})
+	})
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+)
+
+// accessLogEntry is the shape of a single JSON access log line.
+type accessLogEntry struct {
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	Status     int    `json:"status"`
+	Bytes      int    `json:"bytes"`
+	LatencyMs  int64  `json:"latency_ms"`
+	RemoteAddr string `json:"remote_addr"`
+}
+
+// AccessLog returns a middleware that logs each request as a single JSON
+// line containing method, path, status, response size, latency and the
+// remote address. It also sets the X-Remote-Addr response header.
+func AccessLog(logger *log.Logger) Middleware {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("X-Remote-Addr", r.RemoteAddr)
+
+			rec := newStatusRecorder(w)
+			start := time.Now()
+
+			next.ServeHTTP(rec, r)
+
+			entry := accessLogEntry{
+				Method:     r.Method,
+				Path:       r.URL.Path,
+				Status:     rec.status,
+				Bytes:      rec.bytes,
+				LatencyMs:  time.Since(start).Milliseconds(),
+				RemoteAddr: r.RemoteAddr,
+			}
+
+			line, err := json.Marshal(entry)
+			if err != nil {
+				logger.Printf("access log marshal error: %v", err)
+				return
+			}
+			logger.Println(string(line))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAccessLogWritesJSONLine(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(&buf, "", 0)
+
+	handler := AccessLog(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		w.Write([]byte("hello"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/brew", nil)
+	req.RemoteAddr = "203.0.113.1:1234"
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("X-Remote-Addr"); got != "203.0.113.1:1234" {
+		t.Errorf("X-Remote-Addr header = %q, want %q", got, "203.0.113.1:1234")
+	}
+
+	var entry accessLogEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("access log line is not valid JSON: %v (line: %q)", err, buf.String())
+	}
+
+	if entry.Method != http.MethodGet {
+		t.Errorf("Method = %q, want %q", entry.Method, http.MethodGet)
+	}
+	if entry.Path != "/brew" {
+		t.Errorf("Path = %q, want %q", entry.Path, "/brew")
+	}
+	if entry.Status != http.StatusTeapot {
+		t.Errorf("Status = %d, want %d", entry.Status, http.StatusTeapot)
+	}
+	if entry.Bytes != len("hello") {
+		t.Errorf("Bytes = %d, want %d", entry.Bytes, len("hello"))
+	}
+	if entry.RemoteAddr != "203.0.113.1:1234" {
+		t.Errorf("RemoteAddr = %q, want %q", entry.RemoteAddr, "203.0.113.1:1234")
+	}
+}
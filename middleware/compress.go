@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// compressWriter wraps an http.ResponseWriter with a compressing io.Writer
+// and makes sure Content-Length is dropped since the encoded size differs
+// from the original. The deletion is repeated on every write/WriteHeader
+// call (not just once up front) because a handler is free to set
+// Content-Length itself after the middleware has already cleared it.
+type compressWriter struct {
+	http.ResponseWriter
+	writer io.Writer
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	w.Header().Del("Content-Length")
+	return w.writer.Write(b)
+}
+
+// Compress returns a middleware that negotiates gzip or brotli encoding
+// based on the request's Accept-Encoding header, preferring brotli when
+// both are acceptable. Requests without a matching Accept-Encoding are
+// passed through unmodified.
+func Compress() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			accept := r.Header.Get("Accept-Encoding")
+
+			switch {
+			case strings.Contains(accept, "br"):
+				bw := brotli.NewWriter(w)
+				defer bw.Close()
+
+				w.Header().Set("Content-Encoding", "br")
+				w.Header().Del("Content-Length")
+				next.ServeHTTP(&compressWriter{ResponseWriter: w, writer: bw}, r)
+
+			case strings.Contains(accept, "gzip"):
+				gw := gzip.NewWriter(w)
+				defer gw.Close()
+
+				w.Header().Set("Content-Encoding", "gzip")
+				w.Header().Del("Content-Length")
+				next.ServeHTTP(&compressWriter{ResponseWriter: w, writer: gw}, r)
+
+			default:
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
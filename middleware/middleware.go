@@ -0,0 +1,19 @@
+// Package middleware provides composable http.Handler wrappers (access
+// logging, panic recovery, compression negotiation and CORS) that can be
+// chained in a declared order around a route's handler.
+package middleware
+
+import "net/http"
+
+// Middleware wraps an http.Handler with additional behavior.
+type Middleware func(http.Handler) http.Handler
+
+// Chain composes middlewares in the order given: the first middleware in
+// the list is the outermost one, i.e. it runs first on the way in and last
+// on the way out.
+func Chain(h http.Handler, mw ...Middleware) http.Handler {
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i](h)
+	}
+	return h
+}
@@ -0,0 +1,106 @@
+// Package static serves a filesystem directory over HTTP, optionally
+// falling back to index.html for single-page apps, with a byte-budgeted
+// two-queue LRU cache in front of file reads so hot assets are served
+// from memory instead of hitting disk on every request.
+package static
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zzzdong/apireception/metrics"
+)
+
+// Handler serves files out of Root, stripping Prefix from the request
+// path first. When SPAFallback is set, requests for paths that don't
+// exist on disk are served Root/index.html instead of a 404, so
+// client-side routers can handle them.
+type Handler struct {
+	Root        string
+	Prefix      string
+	SPAFallback bool
+
+	cache *twoQueueCache
+}
+
+// NewHandler builds a Handler rooted at dir, with a read cache bounded to
+// cacheBytes total bytes of file content.
+func NewHandler(dir, prefix string, cacheBytes int64, spaFallback bool) *Handler {
+	return &Handler{
+		Root:        dir,
+		Prefix:      prefix,
+		SPAFallback: spaFallback,
+		cache:       newTwoQueueCache(cacheBytes),
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqPath := strings.TrimPrefix(r.URL.Path, h.Prefix)
+	cleaned := filepath.Clean("/" + reqPath)
+
+	data, contentType, err := h.read(cleaned)
+	if err != nil && h.SPAFallback {
+		data, contentType, err = h.read("/index.html")
+	}
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Write(data)
+}
+
+// read returns the file contents for the cleaned request path, serving
+// from the cache when the cached copy is still fresh (same mtime) and
+// otherwise reading through from disk and repopulating the cache.
+func (h *Handler) read(cleaned string) ([]byte, string, error) {
+	full := filepath.Join(h.Root, cleaned)
+
+	info, err := os.Stat(full)
+	if err != nil {
+		return nil, "", err
+	}
+	if info.IsDir() {
+		full = filepath.Join(full, "index.html")
+		if info, err = os.Stat(full); err != nil {
+			return nil, "", err
+		}
+	}
+
+	mtime := info.ModTime().UnixNano()
+
+	if entry, ok := h.cache.get(cleaned); ok && entry.mtime == mtime {
+		metrics.StaticCacheHits.Inc()
+		return entry.value, contentTypeFor(full), nil
+	}
+
+	metrics.StaticCacheMisses.Inc()
+
+	data, err := os.ReadFile(full)
+	if err != nil {
+		return nil, "", err
+	}
+
+	h.cache.set(cleaned, data, mtime)
+	return data, contentTypeFor(full), nil
+}
+
+func contentTypeFor(path string) string {
+	switch filepath.Ext(path) {
+	case ".html":
+		return "text/html; charset=utf-8"
+	case ".css":
+		return "text/css; charset=utf-8"
+	case ".js":
+		return "application/javascript; charset=utf-8"
+	case ".json":
+		return "application/json; charset=utf-8"
+	case ".svg":
+		return "image/svg+xml"
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -0,0 +1,166 @@
+package static
+
+import (
+	"container/list"
+	"sync"
+)
+
+// twoQueueCache is a byte-budgeted 2Q cache: entries are first admitted
+// into a FIFO probationary queue (a1in) and only promoted to an LRU
+// protected queue (am) once they're accessed a second time. A small ghost
+// queue (a1out) remembers keys recently evicted from a1in, without their
+// data, so a key that cycles back in quickly is promoted straight to am
+// instead of restarting probation. This keeps one-off reads from evicting
+// genuinely hot entries, which a plain LRU is prone to.
+type twoQueueCache struct {
+	mu sync.Mutex
+
+	maxBytes  int64
+	a1inBytes int64
+	a1inMax   int64
+	amBytes   int64
+	ghostMax  int
+
+	a1in  *list.List // FIFO of *cacheEntry, least-recent at Back
+	am    *list.List // LRU of *cacheEntry, least-recent at Back
+	ghost *list.List // FIFO of string keys evicted from a1in
+
+	items  map[string]*list.Element // key -> element in a1in or am
+	ghosts map[string]*list.Element // key -> element in ghost
+}
+
+type cacheEntry struct {
+	key    string
+	value  []byte
+	mtime  int64
+	inA1in bool
+}
+
+func newTwoQueueCache(maxBytes int64) *twoQueueCache {
+	return &twoQueueCache{
+		maxBytes: maxBytes,
+		a1inMax:  maxBytes / 4,
+		ghostMax: 512,
+		a1in:     list.New(),
+		am:       list.New(),
+		ghost:    list.New(),
+		items:    make(map[string]*list.Element),
+		ghosts:   make(map[string]*list.Element),
+	}
+}
+
+// get returns the cached value for key, promoting it to the protected
+// queue if it was only in probation.
+func (c *twoQueueCache) get(key string) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*cacheEntry)
+
+	if entry.inA1in {
+		c.a1in.Remove(el)
+		c.a1inBytes -= int64(len(entry.value))
+		entry.inA1in = false
+		c.items[key] = c.am.PushFront(entry)
+		c.amBytes += int64(len(entry.value))
+	} else {
+		c.am.MoveToFront(el)
+	}
+
+	return entry, true
+}
+
+// set inserts or replaces the cached value for key, evicting from the
+// probationary queue first and only touching the protected queue once
+// probation is empty, per the 2Q eviction order.
+func (c *twoQueueCache) set(key string, value []byte, mtime int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remove(key)
+
+	size := int64(len(value))
+
+	if _, wasGhost := c.ghosts[key]; wasGhost {
+		c.removeGhost(key)
+		entry := &cacheEntry{key: key, value: value, mtime: mtime}
+		c.items[key] = c.am.PushFront(entry)
+		c.amBytes += size
+	} else {
+		entry := &cacheEntry{key: key, value: value, mtime: mtime, inA1in: true}
+		c.items[key] = c.a1in.PushFront(entry)
+		c.a1inBytes += size
+	}
+
+	c.evict()
+}
+
+func (c *twoQueueCache) remove(key string) {
+	el, ok := c.items[key]
+	if !ok {
+		return
+	}
+	entry := el.Value.(*cacheEntry)
+	size := int64(len(entry.value))
+
+	if entry.inA1in {
+		c.a1in.Remove(el)
+		c.a1inBytes -= size
+	} else {
+		c.am.Remove(el)
+		c.amBytes -= size
+	}
+	delete(c.items, key)
+}
+
+func (c *twoQueueCache) removeGhost(key string) {
+	if el, ok := c.ghosts[key]; ok {
+		c.ghost.Remove(el)
+		delete(c.ghosts, key)
+	}
+}
+
+func (c *twoQueueCache) addGhost(key string) {
+	c.ghosts[key] = c.ghost.PushFront(key)
+	for c.ghost.Len() > c.ghostMax {
+		back := c.ghost.Back()
+		c.ghost.Remove(back)
+		delete(c.ghosts, back.Value.(string))
+	}
+}
+
+// evict drops entries, oldest probationary first, then oldest protected,
+// until the cache is back within its byte budget. It also caps a1in on its
+// own so a burst of one-off reads can't starve the protected queue of its
+// share of the budget.
+func (c *twoQueueCache) evict() {
+	for c.a1inBytes > c.a1inMax && c.a1in.Len() > 0 {
+		c.evictOldestA1in()
+	}
+
+	for c.a1inBytes+c.amBytes > c.maxBytes && c.a1in.Len() > 0 {
+		c.evictOldestA1in()
+	}
+
+	for c.a1inBytes+c.amBytes > c.maxBytes && c.am.Len() > 0 {
+		back := c.am.Back()
+		entry := back.Value.(*cacheEntry)
+		c.am.Remove(back)
+		c.amBytes -= int64(len(entry.value))
+		delete(c.items, entry.key)
+	}
+}
+
+func (c *twoQueueCache) evictOldestA1in() {
+	back := c.a1in.Back()
+	entry := back.Value.(*cacheEntry)
+	c.a1in.Remove(back)
+	c.a1inBytes -= int64(len(entry.value))
+	delete(c.items, entry.key)
+	c.addGhost(entry.key)
+}
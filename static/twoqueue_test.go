@@ -0,0 +1,76 @@
+package static
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestTwoQueueCacheGetSet(t *testing.T) {
+	c := newTwoQueueCache(1024)
+
+	if _, ok := c.get("a"); ok {
+		t.Fatalf("expected miss on empty cache")
+	}
+
+	c.set("a", []byte("hello"), 1)
+
+	entry, ok := c.get("a")
+	if !ok {
+		t.Fatalf("expected hit after set")
+	}
+	if string(entry.value) != "hello" {
+		t.Fatalf("got value %q, want %q", entry.value, "hello")
+	}
+}
+
+func TestTwoQueueCachePromotesOnSecondAccess(t *testing.T) {
+	c := newTwoQueueCache(1024)
+
+	c.set("a", []byte("hello"), 1)
+	if entry, ok := c.items["a"]; !ok || !entry.Value.(*cacheEntry).inA1in {
+		t.Fatalf("expected entry to start in a1in")
+	}
+
+	c.get("a")
+
+	entry, ok := c.items["a"]
+	if !ok || entry.Value.(*cacheEntry).inA1in {
+		t.Fatalf("expected entry to be promoted to am after a second access")
+	}
+}
+
+func TestTwoQueueCacheEvictsUnderByteBudget(t *testing.T) {
+	// a1inMax is a quarter of maxBytes (6 of 24). Each entry fits on its
+	// own, but the second one pushes a1in to 10 bytes, over its share of
+	// the budget, so the oldest probationary entry is evicted.
+	c := newTwoQueueCache(24)
+
+	c.set("a", make([]byte, 5), 1)
+	c.set("b", make([]byte, 5), 1)
+
+	if _, ok := c.get("a"); ok {
+		t.Fatalf("expected \"a\" to be evicted once a1in's share of the byte budget was exceeded")
+	}
+	if _, ok := c.get("b"); !ok {
+		t.Fatalf("expected \"b\" to remain cached")
+	}
+}
+
+// TestTwoQueueCacheConcurrentAccess exercises get/set from many goroutines
+// on the same keys; run with -race to catch data races in the underlying
+// map/list mutations.
+func TestTwoQueueCacheConcurrentAccess(t *testing.T) {
+	c := newTwoQueueCache(1 << 20)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := "k"
+			c.set(key, []byte("v"), int64(i))
+			c.get(key)
+		}(i)
+	}
+	wg.Wait()
+}